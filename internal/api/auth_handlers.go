@@ -0,0 +1,141 @@
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/tanq16/expenseowl/internal/auth"
+)
+
+type RegisterRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type LoginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type IssueTokenRequest struct {
+	Name string `json:"name"`
+}
+
+type IssueTokenResponse struct {
+	Token string         `json:"token"`
+	Info  *auth.APIToken `json:"info"`
+}
+
+func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		log.Println("HTTP ERROR: Method not allowed")
+		return
+	}
+	var req RegisterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "Invalid request body"})
+		log.Printf("HTTP ERROR: Failed to decode request body: %v\n", err)
+		return
+	}
+	user, err := h.auth.Register(req.Username, req.Password)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		log.Printf("HTTP ERROR: Failed to register user: %v\n", err)
+		return
+	}
+	cookie, err := h.auth.StartSession(user)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "Failed to start session"})
+		log.Printf("HTTP ERROR: Failed to start session: %v\n", err)
+		return
+	}
+	http.SetCookie(w, cookie)
+	writeJSON(w, http.StatusOK, user)
+	log.Printf("HTTP: Registered user %s\n", user.Username)
+}
+
+func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		log.Println("HTTP ERROR: Method not allowed")
+		return
+	}
+	var req LoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "Invalid request body"})
+		log.Printf("HTTP ERROR: Failed to decode request body: %v\n", err)
+		return
+	}
+	user, err := h.auth.Authenticate(req.Username, req.Password)
+	if err != nil {
+		writeJSON(w, http.StatusUnauthorized, ErrorResponse{Error: "Invalid username or password"})
+		log.Printf("HTTP ERROR: Failed login attempt for %s: %v\n", req.Username, err)
+		return
+	}
+	cookie, err := h.auth.StartSession(user)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "Failed to start session"})
+		log.Printf("HTTP ERROR: Failed to start session: %v\n", err)
+		return
+	}
+	http.SetCookie(w, cookie)
+	writeJSON(w, http.StatusOK, user)
+	log.Printf("HTTP: Logged in user %s\n", user.Username)
+}
+
+func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		log.Println("HTTP ERROR: Method not allowed")
+		return
+	}
+	if cookie, err := r.Cookie(auth.SessionCookieName); err == nil {
+		_ = h.auth.EndSession(cookie.Value)
+	}
+	http.SetCookie(w, &http.Cookie{Name: auth.SessionCookieName, Value: "", Path: "/", MaxAge: -1})
+	writeJSON(w, http.StatusOK, map[string]string{"status": "success"})
+	log.Println("HTTP: Logged out user")
+}
+
+func (h *Handler) Me(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		log.Println("HTTP ERROR: Method not allowed")
+		return
+	}
+	user, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, ErrorResponse{Error: "Not authenticated"})
+		return
+	}
+	writeJSON(w, http.StatusOK, user)
+}
+
+func (h *Handler) IssueAPIToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		log.Println("HTTP ERROR: Method not allowed")
+		return
+	}
+	user, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, ErrorResponse{Error: "Not authenticated"})
+		return
+	}
+	var req IssueTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "Invalid request body"})
+		log.Printf("HTTP ERROR: Failed to decode request body: %v\n", err)
+		return
+	}
+	token, info, err := h.auth.IssueAPIToken(user.ID, req.Name)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "Failed to issue API token"})
+		log.Printf("HTTP ERROR: Failed to issue API token: %v\n", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, IssueTokenResponse{Token: token, Info: info})
+	log.Printf("HTTP: Issued API token for user %s\n", user.Username)
+}
@@ -0,0 +1,207 @@
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/tanq16/expenseowl/internal/budget"
+)
+
+func (h *Handler) CreateBudget(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		log.Println("HTTP ERROR: Method not allowed")
+		return
+	}
+	userID, ok := h.currentUserID(w, r)
+	if !ok {
+		return
+	}
+	var req budget.Budget
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "Invalid request body"})
+		log.Printf("HTTP ERROR: Failed to decode request body: %v\n", err)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		log.Printf("HTTP ERROR: Failed to validate budget: %v\n", err)
+		return
+	}
+	req.ID = budget.NewID()
+	req.UserID = userID
+	if err := h.budgets.SaveBudget(&req); err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "Failed to save budget"})
+		log.Printf("HTTP ERROR: Failed to save budget: %v\n", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, &req)
+	log.Printf("HTTP: Created budget %s\n", req.ID)
+}
+
+func (h *Handler) GetBudgets(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		log.Println("HTTP ERROR: Method not allowed")
+		return
+	}
+	userID, ok := h.currentUserID(w, r)
+	if !ok {
+		return
+	}
+	budgets, err := h.budgetsForUser(userID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "Failed to retrieve budgets"})
+		log.Printf("HTTP ERROR: Failed to retrieve budgets: %v\n", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, budgets)
+}
+
+func (h *Handler) EditBudget(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		log.Println("HTTP ERROR: Method not allowed")
+		return
+	}
+	userID, ok := h.currentUserID(w, r)
+	if !ok {
+		return
+	}
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "ID parameter is required"})
+		log.Println("HTTP ERROR: ID parameter is required")
+		return
+	}
+	existing, err := h.ownedBudget(id, userID)
+	if err != nil {
+		if err == budget.ErrBudgetNotFound {
+			writeJSON(w, http.StatusNotFound, ErrorResponse{Error: "Budget not found"})
+			log.Printf("HTTP ERROR: Budget not found: %v\n", err)
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "Failed to retrieve budget"})
+		log.Printf("HTTP ERROR: Failed to retrieve budget: %v\n", err)
+		return
+	}
+	var req budget.Budget
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "Invalid request body"})
+		log.Printf("HTTP ERROR: Failed to decode request body: %v\n", err)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		log.Printf("HTTP ERROR: Failed to validate budget: %v\n", err)
+		return
+	}
+	req.ID = existing.ID
+	req.UserID = existing.UserID
+	req.WindowStart = existing.WindowStart
+	req.FiredThresholds = existing.FiredThresholds
+	if err := h.budgets.SaveBudget(&req); err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "Failed to save budget"})
+		log.Printf("HTTP ERROR: Failed to save budget: %v\n", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, &req)
+	log.Printf("HTTP: Updated budget %s\n", req.ID)
+}
+
+func (h *Handler) DeleteBudget(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		log.Println("HTTP ERROR: Method not allowed")
+		return
+	}
+	userID, ok := h.currentUserID(w, r)
+	if !ok {
+		return
+	}
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "ID parameter is required"})
+		log.Println("HTTP ERROR: ID parameter is required")
+		return
+	}
+	if _, err := h.ownedBudget(id, userID); err != nil {
+		if err == budget.ErrBudgetNotFound {
+			writeJSON(w, http.StatusNotFound, ErrorResponse{Error: "Budget not found"})
+			log.Printf("HTTP ERROR: Budget not found: %v\n", err)
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "Failed to retrieve budget"})
+		log.Printf("HTTP ERROR: Failed to retrieve budget: %v\n", err)
+		return
+	}
+	if err := h.budgets.DeleteBudget(id); err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "Failed to delete budget"})
+		log.Printf("HTTP ERROR: Failed to delete budget: %v\n", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "success"})
+	log.Printf("HTTP: Deleted budget with ID %s\n", id)
+}
+
+func (h *Handler) GetBudgetsStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		log.Println("HTTP ERROR: Method not allowed")
+		return
+	}
+	userID, ok := h.currentUserID(w, r)
+	if !ok {
+		return
+	}
+	budgets, err := h.budgetsForUser(userID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "Failed to retrieve budgets"})
+		log.Printf("HTTP ERROR: Failed to retrieve budgets: %v\n", err)
+		return
+	}
+	expenses, err := h.existingExpenses(r)
+	if err != nil {
+		writeExpensesError(w, err)
+		return
+	}
+	now := time.Now().UTC()
+	statuses := make([]budget.Status, 0, len(budgets))
+	for _, b := range budgets {
+		statuses = append(statuses, budget.StatusOf(b, expenses, now))
+	}
+	writeJSON(w, http.StatusOK, statuses)
+}
+
+// budgetsForUser returns only the budgets owned by userID ("" in
+// single-tenant deployments), since budget.Store has no per-user scoped
+// variant the way storage.ScopedStorage does for expenses.
+func (h *Handler) budgetsForUser(userID string) ([]*budget.Budget, error) {
+	all, err := h.budgets.GetAllBudgets()
+	if err != nil {
+		return nil, err
+	}
+	owned := make([]*budget.Budget, 0, len(all))
+	for _, b := range all {
+		if b.UserID == userID {
+			owned = append(owned, b)
+		}
+	}
+	return owned, nil
+}
+
+// ownedBudget fetches the budget with id, treating it as not found if it
+// belongs to a different user, so one account can't probe another's
+// budget IDs.
+func (h *Handler) ownedBudget(id, userID string) (*budget.Budget, error) {
+	b, err := h.budgets.GetBudget(id)
+	if err != nil {
+		return nil, err
+	}
+	if b.UserID != userID {
+		return nil, budget.ErrBudgetNotFound
+	}
+	return b, nil
+}
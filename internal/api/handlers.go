@@ -8,23 +8,89 @@ import (
 	"strings"
 	"time"
 
+	"github.com/tanq16/expenseowl/internal/auth"
+	"github.com/tanq16/expenseowl/internal/budget"
 	"github.com/tanq16/expenseowl/internal/config"
+	"github.com/tanq16/expenseowl/internal/events"
+	"github.com/tanq16/expenseowl/internal/query"
+	"github.com/tanq16/expenseowl/internal/recurring"
 	"github.com/tanq16/expenseowl/internal/storage"
 	"github.com/tanq16/expenseowl/internal/web"
 )
 
 type Handler struct {
-	storage storage.Storage
-	config  *config.Config
+	storage   storage.Storage
+	config    *config.Config
+	recurring recurring.Store
+	auth      *auth.Service
+	events    *events.Hub
+	budget    *budget.Engine
+	budgets   budget.Store
 }
 
-func NewHandler(s storage.Storage, cfg *config.Config) *Handler {
+func NewHandler(s storage.Storage, cfg *config.Config, recurringStore recurring.Store, authService *auth.Service, eventHub *events.Hub, budgetStore budget.Store, budgetEngine *budget.Engine) *Handler {
 	return &Handler{
-		storage: s,
-		config:  cfg,
+		storage:   s,
+		config:    cfg,
+		recurring: recurringStore,
+		auth:      authService,
+		events:    eventHub,
+		budgets:   budgetStore,
+		budget:    budgetEngine,
 	}
 }
 
+// publish notifies userID's connected /events clients ("" in
+// single-tenant deployments), a no-op if no hub is wired.
+func (h *Handler) publish(eventType string, data interface{}, userID string) {
+	if h.events == nil {
+		return
+	}
+	h.events.Publish(eventType, data, userID)
+}
+
+// requireUser resolves the authenticated user attached by auth.Middleware,
+// writing a 401 response and returning false if the request has none.
+// Callers that already checked scopedStorage() expect a user to always be
+// present, but must still guard against a misconfigured route bypassing
+// the middleware instead of risking a nil dereference.
+func (h *Handler) requireUser(w http.ResponseWriter, r *http.Request) (*auth.User, bool) {
+	user, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, ErrorResponse{Error: "Not authenticated"})
+		log.Println("HTTP ERROR: Not authenticated")
+		return nil, false
+	}
+	return user, true
+}
+
+// scopedStorage returns the storage backend as a storage.ScopedStorage if
+// auth is enabled and the backend supports per-user isolation.
+func (h *Handler) scopedStorage() (storage.ScopedStorage, bool) {
+	if h.auth == nil {
+		return nil, false
+	}
+	scoped, ok := h.storage.(storage.ScopedStorage)
+	return scoped, ok
+}
+
+// currentUserID returns the ID of the authenticated user, or "" for a
+// single-tenant deployment with no auth configured. Budgets and recurring
+// expenses aren't partitioned through storage.ScopedStorage, so their
+// handlers use this directly instead of scopedStorage() to decide which
+// records belong to the caller. It writes a 401 itself and returns
+// ok=false if auth is enabled but the request has no authenticated user.
+func (h *Handler) currentUserID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	if h.auth == nil {
+		return "", true
+	}
+	user, ok := h.requireUser(w, r)
+	if !ok {
+		return "", false
+	}
+	return user.ID, true
+}
+
 type ErrorResponse struct {
 	Error string `json:"error"`
 }
@@ -47,9 +113,23 @@ func (h *Handler) GetCategories(w http.ResponseWriter, r *http.Request) {
 		log.Println("HTTP ERROR: Method not allowed")
 		return
 	}
+	cfg := h.config
+	if scoped, ok := h.scopedStorage(); ok {
+		user, ok := h.requireUser(w, r)
+		if !ok {
+			return
+		}
+		userCfg, err := scoped.GetConfigForUser(user.ID)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "Failed to retrieve config"})
+			log.Printf("HTTP ERROR: Failed to retrieve config: %v\n", err)
+			return
+		}
+		cfg = userCfg
+	}
 	response := ConfigResponse{
-		Categories: h.config.Categories,
-		Currency:   h.config.Currency,
+		Categories: cfg.Categories,
+		Currency:   cfg.Currency,
 	}
 	writeJSON(w, http.StatusOK, response)
 }
@@ -66,8 +146,23 @@ func (h *Handler) EditCategories(w http.ResponseWriter, r *http.Request) {
 		log.Printf("HTTP ERROR: Failed to decode request body: %v\n", err)
 		return
 	}
-	h.config.UpdateCategories(categories)
+	userID := ""
+	if scoped, ok := h.scopedStorage(); ok {
+		user, ok := h.requireUser(w, r)
+		if !ok {
+			return
+		}
+		userID = user.ID
+		if err := scoped.UpdateCategoriesForUser(user.ID, categories); err != nil {
+			writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "Failed to update categories"})
+			log.Printf("HTTP ERROR: Failed to update categories: %v\n", err)
+			return
+		}
+	} else {
+		h.config.UpdateCategories(categories)
+	}
 	writeJSON(w, http.StatusOK, map[string]string{"status": "success"})
+	h.publish(events.CategoriesUpdated, categories, userID)
 	log.Println("HTTP: Updated categories")
 }
 
@@ -83,8 +178,23 @@ func (h *Handler) EditCurrency(w http.ResponseWriter, r *http.Request) {
 		log.Printf("HTTP ERROR: Failed to decode request body: %v\n", err)
 		return
 	}
-	h.config.UpdateCurrency(currency)
+	userID := ""
+	if scoped, ok := h.scopedStorage(); ok {
+		user, ok := h.requireUser(w, r)
+		if !ok {
+			return
+		}
+		userID = user.ID
+		if err := scoped.UpdateCurrencyForUser(user.ID, currency); err != nil {
+			writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "Failed to update currency"})
+			log.Printf("HTTP ERROR: Failed to update currency: %v\n", err)
+			return
+		}
+	} else {
+		h.config.UpdateCurrency(currency)
+	}
 	writeJSON(w, http.StatusOK, map[string]string{"status": "success"})
+	h.publish(events.CurrencyUpdated, currency, userID)
 	log.Println("HTTP: Updated currency")
 }
 
@@ -114,12 +224,44 @@ func (h *Handler) AddExpense(w http.ResponseWriter, r *http.Request) {
 		log.Printf("HTTP ERROR: Failed to validate expense: %v\n", err)
 		return
 	}
+	if scoped, ok := h.scopedStorage(); ok {
+		user, ok := h.requireUser(w, r)
+		if !ok {
+			return
+		}
+		if err := scoped.SaveExpenseForUser(user.ID, expense); err != nil {
+			writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "Failed to save expense"})
+			log.Printf("HTTP ERROR: Failed to save expense: %v\n", err)
+			return
+		}
+		writeJSON(w, http.StatusOK, expense)
+		h.publish(events.ExpenseCreated, expense, user.ID)
+		h.evaluateBudgets(r, expense, user.ID)
+		return
+	}
 	if err := h.storage.SaveExpense(expense); err != nil {
 		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "Failed to save expense"})
 		log.Printf("HTTP ERROR: Failed to save expense: %v\n", err)
 		return
 	}
 	writeJSON(w, http.StatusOK, expense)
+	h.publish(events.ExpenseCreated, expense, "")
+	h.evaluateBudgets(r, expense, "")
+}
+
+// evaluateBudgets recomputes budget utilization after a new expense is
+// saved, enqueuing any newly crossed threshold notifications for budgets
+// owned by userID.
+func (h *Handler) evaluateBudgets(r *http.Request, expense *config.Expense, userID string) {
+	if h.budget == nil {
+		return
+	}
+	allExpenses, err := h.existingExpenses(r)
+	if err != nil {
+		log.Printf("HTTP ERROR: Failed to load expenses for budget evaluation: %v\n", err)
+		return
+	}
+	h.budget.Evaluate(expense, allExpenses, userID)
 }
 
 func (h *Handler) GetExpenses(w http.ResponseWriter, r *http.Request) {
@@ -128,13 +270,113 @@ func (h *Handler) GetExpenses(w http.ResponseWriter, r *http.Request) {
 		log.Println("HTTP ERROR: Method not allowed")
 		return
 	}
-	expenses, err := h.storage.GetAllExpenses()
+	expenses, err := h.existingExpenses(r)
 	if err != nil {
-		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "Failed to retrieve expenses"})
-		log.Printf("HTTP ERROR: Failed to retrieve expenses: %v\n", err)
+		writeExpensesError(w, err)
 		return
 	}
-	writeJSON(w, http.StatusOK, expenses)
+	if r.URL.Query().Get("includeProjected") == "true" {
+		userID, ok := h.currentUserID(w, r)
+		if !ok {
+			return
+		}
+		expenses = append(expenses, h.projectedExpenses(userID)...)
+	}
+	params, hasFilters, err := query.ParseParams(r.URL.Query())
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		log.Printf("HTTP ERROR: Invalid query parameters: %v\n", err)
+		return
+	}
+	if !hasFilters {
+		writeJSON(w, http.StatusOK, expenses)
+		return
+	}
+	result := query.Apply(expenses, params)
+	response := ExpensesResponse{Expenses: result.Expenses}
+	if result.NextCursor != nil {
+		cursor := query.EncodeCursor(*result.NextCursor)
+		response.NextCursor = &cursor
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// ExpensesResponse is returned by GET /expenses when any filter, sort, or
+// pagination param is present.
+type ExpensesResponse struct {
+	Expenses   []*config.Expense `json:"expenses"`
+	NextCursor *string           `json:"nextCursor,omitempty"`
+}
+
+func (h *Handler) GetExpensesAggregate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		log.Println("HTTP ERROR: Method not allowed")
+		return
+	}
+	aggParams, err := query.ParseAggregateParams(r.URL.Query().Get("groupBy"), r.URL.Query().Get("metric"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		log.Printf("HTTP ERROR: Invalid aggregate parameters: %v\n", err)
+		return
+	}
+	expenses, err := h.existingExpenses(r)
+	if err != nil {
+		writeExpensesError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, query.Aggregate(expenses, aggParams))
+}
+
+// projectedExpenses returns the not-yet-materialized occurrences of every
+// recurring expense template owned by userID ("" in single-tenant
+// deployments), for preview in the dashboard.
+func (h *Handler) projectedExpenses(userID string) []*config.Expense {
+	if h.recurring == nil {
+		return nil
+	}
+	templates, err := h.recurringForUser(userID)
+	if err != nil {
+		log.Printf("HTTP ERROR: Failed to load recurring expenses: %v\n", err)
+		return nil
+	}
+	now := time.Now().UTC()
+	var projected []*config.Expense
+	for _, t := range templates {
+		projected = append(projected, t.Upcoming(now)...)
+	}
+	return projected
+}
+
+// recurringForUser returns only the recurring expense templates owned by
+// userID ("" in single-tenant deployments), since recurring.Store has no
+// per-user scoped variant the way storage.ScopedStorage does for expenses.
+func (h *Handler) recurringForUser(userID string) ([]*recurring.RecurringExpense, error) {
+	all, err := h.recurring.GetAllRecurring()
+	if err != nil {
+		return nil, err
+	}
+	owned := make([]*recurring.RecurringExpense, 0, len(all))
+	for _, t := range all {
+		if t.UserID == userID {
+			owned = append(owned, t)
+		}
+	}
+	return owned, nil
+}
+
+// ownedRecurring fetches the recurring expense template with id, treating
+// it as not found if it belongs to a different user, so one account can't
+// probe another's template IDs.
+func (h *Handler) ownedRecurring(id, userID string) (*recurring.RecurringExpense, error) {
+	t, err := h.recurring.GetRecurring(id)
+	if err != nil {
+		return nil, err
+	}
+	if t.UserID != userID {
+		return nil, recurring.ErrRecurringNotFound
+	}
+	return t, nil
 }
 
 func (h *Handler) ServeTableView(w http.ResponseWriter, r *http.Request) {
@@ -189,7 +431,19 @@ func (h *Handler) DeleteExpense(w http.ResponseWriter, r *http.Request) {
 		log.Println("HTTP ERROR: ID parameter is required")
 		return
 	}
-	if err := h.storage.DeleteExpense(id); err != nil {
+	var err error
+	userID := ""
+	if scoped, ok := h.scopedStorage(); ok {
+		user, ok := h.requireUser(w, r)
+		if !ok {
+			return
+		}
+		userID = user.ID
+		err = scoped.DeleteExpenseForUser(user.ID, id)
+	} else {
+		err = h.storage.DeleteExpense(id)
+	}
+	if err != nil {
 		if err == storage.ErrExpenseNotFound {
 			writeJSON(w, http.StatusNotFound, ErrorResponse{Error: "Expense not found"})
 			log.Printf("HTTP ERROR: Expense not found: %v\n", err)
@@ -200,9 +454,27 @@ func (h *Handler) DeleteExpense(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	writeJSON(w, http.StatusOK, map[string]string{"status": "success"})
+	h.publish(events.ExpenseDeleted, map[string]string{"id": id}, userID)
 	log.Printf("HTTP: Deleted expense with ID %s\n", id)
 }
 
+func (h *Handler) Events(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		log.Println("HTTP ERROR: Method not allowed")
+		return
+	}
+	if h.events == nil {
+		http.Error(w, "Event stream not available", http.StatusNotImplemented)
+		return
+	}
+	userID, ok := h.currentUserID(w, r)
+	if !ok {
+		return
+	}
+	h.events.ServeHTTP(w, r, userID)
+}
+
 // Static Handler
 func (h *Handler) ServeStaticFile(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -224,10 +496,9 @@ func (h *Handler) ExportCSV(w http.ResponseWriter, r *http.Request) {
 		log.Println("HTTP ERROR: Method not allowed")
 		return
 	}
-	expenses, err := h.storage.GetAllExpenses()
+	expenses, err := h.existingExpenses(r)
 	if err != nil {
-		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "Failed to retrieve expenses"})
-		log.Printf("HTTP ERROR: Failed to retrieve expenses: %v\n", err)
+		writeExpensesError(w, err)
 		return
 	}
 	w.Header().Set("Content-Type", "text/csv")
@@ -253,10 +524,9 @@ func (h *Handler) ExportJSON(w http.ResponseWriter, r *http.Request) {
 		log.Println("HTTP ERROR: Method not allowed")
 		return
 	}
-	expenses, err := h.storage.GetAllExpenses()
+	expenses, err := h.existingExpenses(r)
 	if err != nil {
-		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "Failed to retrieve expenses"})
-		log.Printf("HTTP ERROR: Failed to retrieve expenses: %v\n", err)
+		writeExpensesError(w, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -272,6 +542,150 @@ func (h *Handler) ExportJSON(w http.ResponseWriter, r *http.Request) {
 	log.Println("HTTP: Exported expenses to JSON")
 }
 
+// Recurring expense handlers
+
+func (h *Handler) CreateRecurringExpense(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		log.Println("HTTP ERROR: Method not allowed")
+		return
+	}
+	userID, ok := h.currentUserID(w, r)
+	if !ok {
+		return
+	}
+	var req recurring.RecurringExpense
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "Invalid request body"})
+		log.Printf("HTTP ERROR: Failed to decode request body: %v\n", err)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		log.Printf("HTTP ERROR: Failed to validate recurring expense: %v\n", err)
+		return
+	}
+	req.ID = recurring.NewID()
+	req.UserID = userID
+	if err := h.recurring.SaveRecurring(&req); err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "Failed to save recurring expense"})
+		log.Printf("HTTP ERROR: Failed to save recurring expense: %v\n", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, &req)
+	log.Printf("HTTP: Created recurring expense %s\n", req.ID)
+}
+
+func (h *Handler) GetRecurringExpenses(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		log.Println("HTTP ERROR: Method not allowed")
+		return
+	}
+	userID, ok := h.currentUserID(w, r)
+	if !ok {
+		return
+	}
+	templates, err := h.recurringForUser(userID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "Failed to retrieve recurring expenses"})
+		log.Printf("HTTP ERROR: Failed to retrieve recurring expenses: %v\n", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, templates)
+}
+
+func (h *Handler) EditRecurringExpense(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		log.Println("HTTP ERROR: Method not allowed")
+		return
+	}
+	userID, ok := h.currentUserID(w, r)
+	if !ok {
+		return
+	}
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "ID parameter is required"})
+		log.Println("HTTP ERROR: ID parameter is required")
+		return
+	}
+	existing, err := h.ownedRecurring(id, userID)
+	if err != nil {
+		if err == recurring.ErrRecurringNotFound {
+			writeJSON(w, http.StatusNotFound, ErrorResponse{Error: "Recurring expense not found"})
+			log.Printf("HTTP ERROR: Recurring expense not found: %v\n", err)
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "Failed to retrieve recurring expense"})
+		log.Printf("HTTP ERROR: Failed to retrieve recurring expense: %v\n", err)
+		return
+	}
+	var req recurring.RecurringExpense
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "Invalid request body"})
+		log.Printf("HTTP ERROR: Failed to decode request body: %v\n", err)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		log.Printf("HTTP ERROR: Failed to validate recurring expense: %v\n", err)
+		return
+	}
+	req.ID = existing.ID
+	req.UserID = existing.UserID
+	req.Occurrences = existing.Occurrences
+	req.LastMaterialized = existing.LastMaterialized
+	if err := h.recurring.SaveRecurring(&req); err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "Failed to save recurring expense"})
+		log.Printf("HTTP ERROR: Failed to save recurring expense: %v\n", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, &req)
+	log.Printf("HTTP: Updated recurring expense %s\n", req.ID)
+}
+
+func (h *Handler) DeleteRecurringExpense(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		log.Println("HTTP ERROR: Method not allowed")
+		return
+	}
+	userID, ok := h.currentUserID(w, r)
+	if !ok {
+		return
+	}
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "ID parameter is required"})
+		log.Println("HTTP ERROR: ID parameter is required")
+		return
+	}
+	if _, err := h.ownedRecurring(id, userID); err != nil {
+		if err == recurring.ErrRecurringNotFound {
+			writeJSON(w, http.StatusNotFound, ErrorResponse{Error: "Recurring expense not found"})
+			log.Printf("HTTP ERROR: Recurring expense not found: %v\n", err)
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "Failed to retrieve recurring expense"})
+		log.Printf("HTTP ERROR: Failed to retrieve recurring expense: %v\n", err)
+		return
+	}
+	if err := h.recurring.DeleteRecurring(id); err != nil {
+		if err == recurring.ErrRecurringNotFound {
+			writeJSON(w, http.StatusNotFound, ErrorResponse{Error: "Recurring expense not found"})
+			log.Printf("HTTP ERROR: Recurring expense not found: %v\n", err)
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "Failed to delete recurring expense"})
+		log.Printf("HTTP ERROR: Failed to delete recurring expense: %v\n", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "success"})
+	log.Printf("HTTP: Deleted recurring expense with ID %s\n", id)
+}
+
 func writeJSON(w http.ResponseWriter, status int, v interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
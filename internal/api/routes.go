@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/tanq16/expenseowl/internal/budget"
+	"github.com/tanq16/expenseowl/internal/recurring"
+)
+
+// RegisterRoutes mounts every handler exposed by Handler onto mux. It is
+// the single place route paths and methods are decided, so adding a
+// handler without a matching line here is immediately obvious in review.
+func RegisterRoutes(mux *http.ServeMux, h *Handler) {
+	mux.HandleFunc("GET /categories", h.GetCategories)
+	mux.HandleFunc("PUT /categories", h.EditCategories)
+	mux.HandleFunc("PUT /currency", h.EditCurrency)
+
+	mux.HandleFunc("GET /expenses", h.GetExpenses)
+	mux.HandleFunc("PUT /expenses", h.AddExpense)
+	mux.HandleFunc("DELETE /expenses", h.DeleteExpense)
+	mux.HandleFunc("GET /expenses/aggregate", h.GetExpensesAggregate)
+
+	mux.HandleFunc("GET /export/csv", h.ExportCSV)
+	mux.HandleFunc("GET /export/json", h.ExportJSON)
+	mux.HandleFunc("POST /import", h.ImportExpenses)
+
+	mux.HandleFunc("POST /recurring", h.CreateRecurringExpense)
+	mux.HandleFunc("GET /recurring", h.GetRecurringExpenses)
+	mux.HandleFunc("PUT /recurring", h.EditRecurringExpense)
+	mux.HandleFunc("DELETE /recurring", h.DeleteRecurringExpense)
+
+	mux.HandleFunc("POST /budgets", h.CreateBudget)
+	mux.HandleFunc("GET /budgets", h.GetBudgets)
+	mux.HandleFunc("PUT /budgets", h.EditBudget)
+	mux.HandleFunc("DELETE /budgets", h.DeleteBudget)
+	mux.HandleFunc("GET /budgets/status", h.GetBudgetsStatus)
+
+	mux.HandleFunc("GET /events", h.Events)
+
+	if h.auth != nil {
+		mux.HandleFunc("POST /auth/register", h.Register)
+		mux.HandleFunc("POST /auth/login", h.Login)
+		mux.HandleFunc("POST /auth/logout", h.Logout)
+		mux.HandleFunc("GET /auth/me", h.Me)
+		mux.HandleFunc("POST /auth/tokens", h.IssueAPIToken)
+	}
+
+	mux.HandleFunc("GET /table", h.ServeTableView)
+	mux.HandleFunc("GET /settings", h.ServeSettingsPage)
+	mux.HandleFunc("GET /api-setup", h.ServeAPISetupView)
+	mux.HandleFunc("GET /static/", h.ServeStaticFile)
+}
+
+// authAllowlist is the set of path prefixes auth.Service.Middleware lets
+// through without a session or API token, so login/registration and
+// static assets remain reachable from a logged-out browser.
+var authAllowlist = []string{
+	"/auth/register",
+	"/auth/login",
+	"/static/",
+	"/api-setup",
+}
+
+// Bootstrap wires the recurring scheduler and budget engine into the
+// running process and returns the fully-routed http.Handler for the
+// server to mount. It launches both background workers in goroutines
+// that exit when ctx is canceled, satisfying the "background scheduler
+// goroutine ... launched on startup" requirement for recurring expenses
+// and draining the budget engine's notification queue for chunk0-6.
+func Bootstrap(ctx context.Context, h *Handler, scheduler *recurring.Scheduler, engine *budget.Engine) http.Handler {
+	mux := http.NewServeMux()
+	RegisterRoutes(mux, h)
+
+	if scheduler != nil {
+		go scheduler.Run(ctx)
+	}
+	if engine != nil {
+		go engine.Run(ctx)
+	}
+
+	var handler http.Handler = mux
+	if h.auth != nil {
+		handler = h.auth.Middleware(authAllowlist)(mux)
+	}
+
+	log.Println("api: routes registered and background workers started")
+	return handler
+}
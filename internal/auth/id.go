@@ -0,0 +1,15 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// NewID generates a random identifier for a new User.
+func NewID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return hex.EncodeToString([]byte("00000000"))
+	}
+	return hex.EncodeToString(b)
+}
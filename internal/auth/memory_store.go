@@ -0,0 +1,142 @@
+package auth
+
+import (
+	"sync"
+	"time"
+)
+
+// MemoryStore is an in-memory Store, suitable for a single-process
+// deployment or as a reference implementation for a persistent backend.
+type MemoryStore struct {
+	mu       sync.Mutex
+	users    map[string]*User
+	sessions map[string]*Session
+	tokens   map[string]*APIToken
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		users:    make(map[string]*User),
+		sessions: make(map[string]*Session),
+		tokens:   make(map[string]*APIToken),
+	}
+}
+
+func (m *MemoryStore) CreateUser(user *User) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, u := range m.users {
+		if u.Username == user.Username {
+			return ErrUserExists
+		}
+	}
+	copy := *user
+	m.users[user.ID] = &copy
+	return nil
+}
+
+func (m *MemoryStore) GetUserByUsername(username string) (*User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, u := range m.users {
+		if u.Username == username {
+			copy := *u
+			return &copy, nil
+		}
+	}
+	return nil, ErrUserNotFound
+}
+
+func (m *MemoryStore) GetUserByID(id string) (*User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	u, ok := m.users[id]
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+	copy := *u
+	return &copy, nil
+}
+
+func (m *MemoryStore) CreateSession(session *Session) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	copy := *session
+	m.sessions[session.ID] = &copy
+	return nil
+}
+
+func (m *MemoryStore) GetSession(id string) (*Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.sessions[id]
+	if !ok {
+		return nil, ErrSessionNotFound
+	}
+	if time.Now().UTC().After(s.ExpiresAt) {
+		return nil, ErrSessionExpired
+	}
+	copy := *s
+	return &copy, nil
+}
+
+func (m *MemoryStore) DeleteSession(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.sessions, id)
+	return nil
+}
+
+func (m *MemoryStore) CreateAPIToken(token *APIToken) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	copy := *token
+	m.tokens[token.ID] = &copy
+	return nil
+}
+
+func (m *MemoryStore) GetAPITokenByHash(tokenHash string) (*APIToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, t := range m.tokens {
+		if t.TokenHash == tokenHash {
+			copy := *t
+			return &copy, nil
+		}
+	}
+	return nil, ErrTokenNotFound
+}
+
+func (m *MemoryStore) ListAPITokens(userID string) ([]*APIToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []*APIToken
+	for _, t := range m.tokens {
+		if t.UserID == userID {
+			copy := *t
+			result = append(result, &copy)
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) DeleteAPIToken(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.tokens[id]; !ok {
+		return ErrTokenNotFound
+	}
+	delete(m.tokens, id)
+	return nil
+}
+
+func (m *MemoryStore) TouchAPIToken(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t, ok := m.tokens[id]
+	if !ok {
+		return ErrTokenNotFound
+	}
+	t.LastUsed = time.Now().UTC()
+	return nil
+}
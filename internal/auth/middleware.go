@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+type contextKey string
+
+const userContextKey contextKey = "auth.user"
+
+// Middleware resolves the caller's session cookie or `Authorization:
+// Bearer` API token into a User and attaches it to the request context.
+// Requests without valid credentials are rejected with 401, except for
+// paths in the allowlist (login/register/static assets).
+func (s *Service) Middleware(allowlist []string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, path := range allowlist {
+				if strings.HasPrefix(r.URL.Path, path) {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+			user, err := s.authenticateRequest(r)
+			if err != nil {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			ctx := context.WithValue(r.Context(), userContextKey, user)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func (s *Service) authenticateRequest(r *http.Request) (*User, error) {
+	if header := r.Header.Get("Authorization"); strings.HasPrefix(header, "Bearer ") {
+		return s.UserFromAPIToken(strings.TrimPrefix(header, "Bearer "))
+	}
+	cookie, err := r.Cookie(SessionCookieName)
+	if err != nil {
+		return nil, ErrSessionNotFound
+	}
+	return s.UserFromSession(cookie.Value)
+}
+
+// UserFromContext returns the authenticated user attached by Middleware.
+func UserFromContext(ctx context.Context) (*User, bool) {
+	user, ok := ctx.Value(userContextKey).(*User)
+	return user, ok
+}
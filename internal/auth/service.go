@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"os"
+	"time"
+)
+
+// Service wires the Store, password hashing, and token issuance together
+// for use by the API handlers and middleware.
+type Service struct {
+	store  Store
+	tokens *TokenIssuer
+}
+
+func NewService(store Store, tokenSecret []byte) *Service {
+	return &Service{store: store, tokens: NewTokenIssuer(tokenSecret)}
+}
+
+// Register creates a new, non-admin user account.
+func (s *Service) Register(username, password string) (*User, error) {
+	if _, err := s.store.GetUserByUsername(username); err == nil {
+		return nil, ErrUserExists
+	}
+	hash, err := HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+	user := &User{
+		ID:           NewID(),
+		Username:     username,
+		PasswordHash: hash,
+		CreatedAt:    time.Now().UTC(),
+	}
+	if err := s.store.CreateUser(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// Authenticate verifies a username/password pair and returns the user.
+func (s *Service) Authenticate(username, password string) (*User, error) {
+	user, err := s.store.GetUserByUsername(username)
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+	if !VerifyPassword(user.PasswordHash, password) {
+		return nil, ErrInvalidCredentials
+	}
+	return user, nil
+}
+
+// IssueAPIToken mints a new long-lived API token for the user and
+// persists its hash so it can be looked up on future requests.
+func (s *Service) IssueAPIToken(userID, name string) (string, *APIToken, error) {
+	id := HashToken(userID + name + time.Now().UTC().String())[:16]
+	claims := Claims{UserID: userID, TokenID: id, IssuedAt: time.Now().UTC()}
+	token, tokenHash, err := s.tokens.Issue(claims)
+	if err != nil {
+		return "", nil, err
+	}
+	apiToken := &APIToken{
+		ID:        id,
+		UserID:    userID,
+		Name:      name,
+		TokenHash: tokenHash,
+		CreatedAt: claims.IssuedAt,
+	}
+	if err := s.store.CreateAPIToken(apiToken); err != nil {
+		return "", nil, err
+	}
+	return token, apiToken, nil
+}
+
+// UserFromAPIToken verifies a bearer token and returns its owning user.
+func (s *Service) UserFromAPIToken(token string) (*User, error) {
+	claims, err := s.tokens.Verify(token)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := s.store.GetAPITokenByHash(HashToken(token))
+	if err != nil {
+		return nil, ErrTokenNotFound
+	}
+	_ = s.store.TouchAPIToken(stored.ID)
+	return s.store.GetUserByID(claims.UserID)
+}
+
+// BootstrapAdmin seeds an admin account from EXPENSEOWL_ADMIN_USERNAME /
+// EXPENSEOWL_ADMIN_PASSWORD if no account with that username exists yet.
+func (s *Service) BootstrapAdmin() error {
+	username := os.Getenv("EXPENSEOWL_ADMIN_USERNAME")
+	password := os.Getenv("EXPENSEOWL_ADMIN_PASSWORD")
+	if username == "" || password == "" {
+		return nil
+	}
+	if _, err := s.store.GetUserByUsername(username); err == nil {
+		return nil
+	}
+	hash, err := HashPassword(password)
+	if err != nil {
+		return err
+	}
+	admin := &User{
+		ID:           NewID(),
+		Username:     username,
+		PasswordHash: hash,
+		IsAdmin:      true,
+		CreatedAt:    time.Now().UTC(),
+	}
+	return s.store.CreateUser(admin)
+}
@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+)
+
+const (
+	SessionCookieName = "expenseowl_session"
+	sessionTTL        = 30 * 24 * time.Hour
+)
+
+func newSessionID() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// StartSession creates and persists a new session for the user and
+// returns the cookie to set on the response.
+func (s *Service) StartSession(user *User) (*http.Cookie, error) {
+	id, err := newSessionID()
+	if err != nil {
+		return nil, err
+	}
+	session := &Session{
+		ID:        id,
+		UserID:    user.ID,
+		ExpiresAt: time.Now().UTC().Add(sessionTTL),
+	}
+	if err := s.store.CreateSession(session); err != nil {
+		return nil, err
+	}
+	return &http.Cookie{
+		Name:     SessionCookieName,
+		Value:    session.ID,
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+		Expires:  session.ExpiresAt,
+	}, nil
+}
+
+// EndSession deletes the session backing the cookie, if any.
+func (s *Service) EndSession(id string) error {
+	return s.store.DeleteSession(id)
+}
+
+// UserFromSession resolves the cookie value to its owning user.
+func (s *Service) UserFromSession(id string) (*User, error) {
+	session, err := s.store.GetSession(id)
+	if err != nil {
+		return nil, err
+	}
+	if time.Now().UTC().After(session.ExpiresAt) {
+		_ = s.store.DeleteSession(session.ID)
+		return nil, ErrSessionExpired
+	}
+	return s.store.GetUserByID(session.UserID)
+}
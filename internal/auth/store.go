@@ -0,0 +1,20 @@
+package auth
+
+// Store persists accounts, sessions, and API tokens. A storage backend
+// that wants to support multi-user mode implements this alongside
+// storage.Storage.
+type Store interface {
+	CreateUser(user *User) error
+	GetUserByUsername(username string) (*User, error)
+	GetUserByID(id string) (*User, error)
+
+	CreateSession(session *Session) error
+	GetSession(id string) (*Session, error)
+	DeleteSession(id string) error
+
+	CreateAPIToken(token *APIToken) error
+	GetAPITokenByHash(tokenHash string) (*APIToken, error)
+	ListAPITokens(userID string) ([]*APIToken, error)
+	DeleteAPIToken(id string) error
+	TouchAPIToken(id string) error
+}
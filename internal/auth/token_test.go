@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenIssuerVerifyRoundTrip(t *testing.T) {
+	issuer := NewTokenIssuer([]byte("test-secret"))
+	claims := Claims{UserID: "u1", TokenID: "t1", IssuedAt: time.Now().UTC()}
+	token, hash, err := issuer.Issue(claims)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	if hash != HashToken(token) {
+		t.Fatalf("returned hash does not match HashToken(token)")
+	}
+	got, err := issuer.Verify(token)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if got.UserID != claims.UserID || got.TokenID != claims.TokenID {
+		t.Fatalf("verified claims mismatch: got %+v, want %+v", got, claims)
+	}
+}
+
+func TestTokenIssuerVerifyRejectsTamperedSignature(t *testing.T) {
+	issuer := NewTokenIssuer([]byte("test-secret"))
+	token, _, err := issuer.Issue(Claims{UserID: "u1", TokenID: "t1"})
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	tampered := token[:len(token)-1] + "x"
+	if _, err := issuer.Verify(tampered); err != ErrInvalidToken {
+		t.Fatalf("expected ErrInvalidToken for a tampered signature, got %v", err)
+	}
+}
+
+func TestTokenIssuerVerifyRejectsWrongSecret(t *testing.T) {
+	issuer := NewTokenIssuer([]byte("secret-a"))
+	token, _, err := issuer.Issue(Claims{UserID: "u1", TokenID: "t1"})
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	other := NewTokenIssuer([]byte("secret-b"))
+	if _, err := other.Verify(token); err != ErrInvalidToken {
+		t.Fatalf("expected ErrInvalidToken when verifying with a different secret, got %v", err)
+	}
+}
+
+func TestTokenIssuerVerifyRejectsExpiredToken(t *testing.T) {
+	issuer := NewTokenIssuer([]byte("test-secret"))
+	claims := Claims{
+		UserID:    "u1",
+		TokenID:   "t1",
+		IssuedAt:  time.Now().UTC().Add(-2 * time.Hour),
+		ExpiresAt: time.Now().UTC().Add(-time.Hour),
+	}
+	token, _, err := issuer.Issue(claims)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	if _, err := issuer.Verify(token); err != ErrInvalidToken {
+		t.Fatalf("expected ErrInvalidToken for an expired token, got %v", err)
+	}
+}
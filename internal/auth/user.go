@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"errors"
+	"time"
+)
+
+// User is an account that owns a private set of expenses, categories, and
+// currency preferences.
+type User struct {
+	ID           string    `json:"id"`
+	Username     string    `json:"username"`
+	PasswordHash string    `json:"-"`
+	IsAdmin      bool      `json:"isAdmin"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// APIToken is a long-lived credential a user can issue for scripted access
+// to the API, used in place of a session cookie.
+type APIToken struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"userId"`
+	Name      string    `json:"name"`
+	TokenHash string    `json:"-"`
+	CreatedAt time.Time `json:"createdAt"`
+	LastUsed  time.Time `json:"lastUsed,omitempty"`
+}
+
+// Session is a server-side record backing a browser session cookie.
+type Session struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"userId"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+var (
+	ErrUserNotFound       = errors.New("user not found")
+	ErrUserExists         = errors.New("username already taken")
+	ErrInvalidCredentials = errors.New("invalid username or password")
+	ErrSessionNotFound    = errors.New("session not found")
+	ErrSessionExpired     = errors.New("session expired")
+	ErrTokenNotFound      = errors.New("api token not found")
+)
@@ -0,0 +1,90 @@
+package budget
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Scope determines whether a Budget caps one category or all spending.
+type Scope string
+
+const (
+	ScopeOverall  Scope = "overall"
+	ScopeCategory Scope = "category"
+)
+
+// Period is the calendar window (week starting Monday, month, or year) a
+// Budget's cap applies to.
+type Period string
+
+const (
+	Weekly  Period = "weekly"
+	Monthly Period = "monthly"
+	Yearly  Period = "yearly"
+)
+
+// Budget caps spending, overall or for one category, over the current
+// calendar week, month, or year, and fires a notification each time
+// utilization crosses one of Thresholds.
+type Budget struct {
+	ID              string    `json:"id"`
+	UserID          string    `json:"userId,omitempty"`
+	Name            string    `json:"name"`
+	Scope           Scope     `json:"scope"`
+	Category        string    `json:"category,omitempty"`
+	Period          Period    `json:"period"`
+	Cap             float64   `json:"cap"`
+	Thresholds      []int     `json:"thresholds"`
+	WindowStart     time.Time `json:"windowStart,omitempty"`
+	FiredThresholds []int     `json:"firedThresholds,omitempty"`
+}
+
+var ErrBudgetNotFound = errors.New("budget not found")
+
+func (b *Budget) Validate() error {
+	if strings.TrimSpace(b.Name) == "" {
+		return errors.New("name cannot be empty")
+	}
+	switch b.Scope {
+	case ScopeOverall:
+	case ScopeCategory:
+		if strings.TrimSpace(b.Category) == "" {
+			return errors.New("category is required when scope is \"category\"")
+		}
+	default:
+		return fmt.Errorf("invalid scope: %s", b.Scope)
+	}
+	switch b.Period {
+	case Weekly, Monthly, Yearly:
+	default:
+		return fmt.Errorf("invalid period: %s", b.Period)
+	}
+	if b.Cap <= 0 {
+		return errors.New("cap must be greater than zero")
+	}
+	for _, t := range b.Thresholds {
+		if t <= 0 || t > 500 {
+			return fmt.Errorf("invalid threshold percentage: %d", t)
+		}
+	}
+	return nil
+}
+
+// windowStart returns the start of the rolling window containing now.
+func (b *Budget) windowStart(now time.Time) time.Time {
+	now = now.UTC()
+	switch b.Period {
+	case Weekly:
+		weekday := int(now.Weekday())
+		if weekday == 0 {
+			weekday = 7
+		}
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -(weekday - 1))
+	case Yearly:
+		return time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	default: // Monthly
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+}
@@ -0,0 +1,106 @@
+package budget
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/tanq16/expenseowl/internal/config"
+)
+
+// Engine evaluates budgets against newly saved expenses and dispatches
+// threshold-crossing notifications to its configured Sinks asynchronously,
+// so delivery never blocks the request that saved the expense.
+type Engine struct {
+	store Store
+	sinks []Sink
+	queue chan Notification
+
+	// mu serializes Evaluate calls: AddExpense runs it synchronously per
+	// request, so concurrent requests touching the same budget would
+	// otherwise race reading, mutating, and persisting FiredThresholds.
+	mu sync.Mutex
+}
+
+func NewEngine(store Store, sinks ...Sink) *Engine {
+	return &Engine{store: store, sinks: sinks, queue: make(chan Notification, 64)}
+}
+
+// Run drains queued notifications to every configured sink until ctx is
+// canceled.
+func (e *Engine) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("budget: engine stopped")
+			return
+		case n := <-e.queue:
+			for _, sink := range e.sinks {
+				if err := sink.Send(n); err != nil {
+					log.Printf("budget: failed to deliver notification for %s: %v\n", n.Budget.Name, err)
+				}
+			}
+		}
+	}
+}
+
+// Evaluate recomputes the utilization of every budget owned by userID
+// ("" in single-tenant deployments) that's affected by expense, and
+// enqueues a notification for each threshold crossed for the first time
+// in the current window.
+func (e *Engine) Evaluate(expense *config.Expense, allExpenses []*config.Expense, userID string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	budgets, err := e.store.GetAllBudgets()
+	if err != nil {
+		log.Printf("budget: failed to load budgets: %v\n", err)
+		return
+	}
+	now := time.Now().UTC()
+	for _, b := range budgets {
+		if b.UserID != userID {
+			continue
+		}
+		if b.Scope == ScopeCategory && b.Category != expense.Category {
+			continue
+		}
+		e.evaluateOne(b, allExpenses, now)
+	}
+}
+
+func (e *Engine) evaluateOne(b *Budget, allExpenses []*config.Expense, now time.Time) {
+	total, windowStart := Usage(b, allExpenses, now)
+	if !windowStart.Equal(b.WindowStart) {
+		b.WindowStart = windowStart
+		b.FiredThresholds = nil
+	}
+	percent := 100 * total / b.Cap
+	changed := false
+	for _, threshold := range b.Thresholds {
+		if percent < float64(threshold) || containsInt(b.FiredThresholds, threshold) {
+			continue
+		}
+		b.FiredThresholds = append(b.FiredThresholds, threshold)
+		changed = true
+		select {
+		case e.queue <- Notification{Budget: b, Threshold: threshold, Percent: percent, Spent: total}:
+		default:
+			log.Printf("budget: notification queue full, dropping alert for %s\n", b.Name)
+		}
+	}
+	if changed {
+		if err := e.store.SaveBudget(b); err != nil {
+			log.Printf("budget: failed to persist budget %s: %v\n", b.ID, err)
+		}
+	}
+}
+
+func containsInt(list []int, v int) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
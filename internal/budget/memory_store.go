@@ -0,0 +1,54 @@
+package budget
+
+import "sync"
+
+// MemoryStore is an in-memory Store, suitable for a single-process
+// deployment or as a reference implementation for a persistent backend.
+type MemoryStore struct {
+	mu      sync.Mutex
+	budgets map[string]*Budget
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{budgets: make(map[string]*Budget)}
+}
+
+func (m *MemoryStore) GetAllBudgets() ([]*Budget, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	result := make([]*Budget, 0, len(m.budgets))
+	for _, b := range m.budgets {
+		copy := *b
+		result = append(result, &copy)
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) GetBudget(id string) (*Budget, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	b, ok := m.budgets[id]
+	if !ok {
+		return nil, ErrBudgetNotFound
+	}
+	copy := *b
+	return &copy, nil
+}
+
+func (m *MemoryStore) SaveBudget(budget *Budget) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	copy := *budget
+	m.budgets[budget.ID] = &copy
+	return nil
+}
+
+func (m *MemoryStore) DeleteBudget(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.budgets[id]; !ok {
+		return ErrBudgetNotFound
+	}
+	delete(m.budgets, id)
+	return nil
+}
@@ -0,0 +1,35 @@
+package budget
+
+import (
+	"fmt"
+	"net/smtp"
+)
+
+// SMTPConfig holds the outbound mail server settings for SMTPSink.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+// SMTPSink emails the notification to the configured recipients.
+type SMTPSink struct {
+	cfg SMTPConfig
+}
+
+func NewSMTPSink(cfg SMTPConfig) *SMTPSink {
+	return &SMTPSink{cfg: cfg}
+}
+
+func (s *SMTPSink) Send(n Notification) error {
+	addr := fmt.Sprintf("%s:%d", s.cfg.Host, s.cfg.Port)
+	auth := smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)
+	subject := fmt.Sprintf("Budget %q crossed %d%%", n.Budget.Name, n.Threshold)
+	body := fmt.Sprintf("%s has spent %.2f (%.0f%% of %.2f) in the current %s window.",
+		n.Budget.Name, n.Spent, n.Percent, n.Budget.Cap, n.Budget.Period)
+	msg := []byte(fmt.Sprintf("Subject: %s\r\n\r\n%s", subject, body))
+	return smtp.SendMail(addr, auth, s.cfg.From, s.cfg.To, msg)
+}
@@ -0,0 +1,9 @@
+package budget
+
+// Store persists Budget definitions and their threshold-firing state.
+type Store interface {
+	GetAllBudgets() ([]*Budget, error)
+	GetBudget(id string) (*Budget, error)
+	SaveBudget(budget *Budget) error
+	DeleteBudget(id string) error
+}
@@ -0,0 +1,42 @@
+package budget
+
+import (
+	"time"
+
+	"github.com/tanq16/expenseowl/internal/config"
+)
+
+// Status is a Budget's current-window utilization, returned from
+// GET /budgets/status.
+type Status struct {
+	Budget     *Budget `json:"budget"`
+	Spent      float64 `json:"spent"`
+	Percent    float64 `json:"percent"`
+	WindowFrom string  `json:"windowFrom"`
+}
+
+// Usage sums expenses from the start of the budget's current calendar
+// window up to now, filtered by category for category-scoped budgets.
+func Usage(b *Budget, expenses []*config.Expense, now time.Time) (total float64, windowStart time.Time) {
+	windowStart = b.windowStart(now)
+	for _, e := range expenses {
+		if e.Date.Before(windowStart) || e.Date.After(now) {
+			continue
+		}
+		if b.Scope == ScopeCategory && e.Category != b.Category {
+			continue
+		}
+		total += e.Amount
+	}
+	return total, windowStart
+}
+
+func StatusOf(b *Budget, expenses []*config.Expense, now time.Time) Status {
+	total, windowStart := Usage(b, expenses, now)
+	return Status{
+		Budget:     b,
+		Spent:      total,
+		Percent:    100 * total / b.Cap,
+		WindowFrom: windowStart.Format("2006-01-02"),
+	}
+}
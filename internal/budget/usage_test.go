@@ -0,0 +1,61 @@
+package budget
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tanq16/expenseowl/internal/config"
+)
+
+func TestUsageExcludesExpensesBeforeWindowStart(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	b := &Budget{Scope: ScopeOverall, Period: Monthly, Cap: 1000}
+	expenses := []*config.Expense{
+		{Amount: 50, Date: time.Date(2026, 2, 28, 23, 59, 59, 0, time.UTC)},
+		{Amount: 75, Date: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	total, windowStart := Usage(b, expenses, now)
+	if !windowStart.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected windowStart: %s", windowStart)
+	}
+	if total != 75 {
+		t.Fatalf("expected only the in-window expense counted, got %v", total)
+	}
+}
+
+func TestUsageExcludesExpensesAfterNow(t *testing.T) {
+	now := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+	b := &Budget{Scope: ScopeOverall, Period: Monthly, Cap: 1000}
+	expenses := []*config.Expense{
+		{Amount: 200, Date: now.AddDate(0, 0, 1)},
+	}
+	total, _ := Usage(b, expenses, now)
+	if total != 0 {
+		t.Fatalf("expected future-dated expense to be excluded, got %v", total)
+	}
+}
+
+func TestUsageCategoryScopeFiltersOtherCategories(t *testing.T) {
+	now := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+	b := &Budget{Scope: ScopeCategory, Category: "Groceries", Period: Monthly, Cap: 500}
+	expenses := []*config.Expense{
+		{Amount: 100, Category: "Groceries", Date: now},
+		{Amount: 300, Category: "Rent", Date: now},
+	}
+	total, _ := Usage(b, expenses, now)
+	if total != 100 {
+		t.Fatalf("expected only Groceries expenses counted, got %v", total)
+	}
+}
+
+func TestUsageWeeklyWindowStartsOnMonday(t *testing.T) {
+	now := time.Date(2026, 3, 18, 0, 0, 0, 0, time.UTC) // a Wednesday
+	b := &Budget{Scope: ScopeOverall, Period: Weekly, Cap: 100}
+	_, windowStart := Usage(b, nil, now)
+	if windowStart.Weekday() != time.Monday {
+		t.Fatalf("expected weekly window to start on Monday, got %s", windowStart.Weekday())
+	}
+	if windowStart.After(now) {
+		t.Fatalf("windowStart %s must not be after now %s", windowStart, now)
+	}
+}
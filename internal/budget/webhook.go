@@ -0,0 +1,86 @@
+package budget
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Notification describes a single threshold crossing for delivery to a
+// Sink.
+type Notification struct {
+	Budget    *Budget `json:"budget"`
+	Threshold int     `json:"threshold"`
+	Percent   float64 `json:"percent"`
+	Spent     float64 `json:"spent"`
+}
+
+// Sink delivers a budget threshold Notification to an external system.
+type Sink interface {
+	Send(n Notification) error
+}
+
+// WebhookSink POSTs the notification as JSON, signed with HMAC-SHA256 over
+// a shared secret, retrying with exponential backoff on failure.
+type WebhookSink struct {
+	URL        string
+	Secret     string
+	Client     *http.Client
+	MaxRetries int
+}
+
+func NewWebhookSink(url, secret string) *WebhookSink {
+	return &WebhookSink{
+		URL:        url,
+		Secret:     secret,
+		Client:     &http.Client{Timeout: 10 * time.Second},
+		MaxRetries: 5,
+	}
+}
+
+func (w *WebhookSink) Send(n Notification) error {
+	payload, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	signature := signPayload(w.Secret, payload)
+
+	var lastErr error
+	for attempt := 0; attempt <= w.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff(attempt))
+		}
+		req, err := http.NewRequest(http.MethodPost, w.URL, bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-ExpenseOwl-Signature", signature)
+		resp, err := w.Client.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil
+		}
+		lastErr = fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return lastErr
+}
+
+func signPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func backoff(attempt int) time.Duration {
+	return time.Duration(1<<uint(attempt)) * time.Second
+}
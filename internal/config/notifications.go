@@ -0,0 +1,14 @@
+package config
+
+// NotificationConfig configures where budget threshold alerts are
+// delivered. Either sink may be left unset to disable it.
+type NotificationConfig struct {
+	WebhookURL    string   `json:"webhookUrl,omitempty"`
+	WebhookSecret string   `json:"webhookSecret,omitempty"`
+	SMTPHost      string   `json:"smtpHost,omitempty"`
+	SMTPPort      int      `json:"smtpPort,omitempty"`
+	SMTPUsername  string   `json:"smtpUsername,omitempty"`
+	SMTPPassword  string   `json:"smtpPassword,omitempty"`
+	SMTPFrom      string   `json:"smtpFrom,omitempty"`
+	SMTPTo        []string `json:"smtpTo,omitempty"`
+}
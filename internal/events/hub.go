@@ -0,0 +1,76 @@
+package events
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// Event types broadcast to subscribed dashboard/table clients.
+const (
+	ExpenseCreated    = "expense.created"
+	ExpenseDeleted    = "expense.deleted"
+	CategoriesUpdated = "categories.updated"
+	CurrencyUpdated   = "currency.updated"
+)
+
+// Event is the JSON payload sent over the SSE stream.
+type Event struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+type client struct {
+	userID   string
+	messages chan []byte
+}
+
+// Hub is a small pub/sub broadcaster: publishers call Publish, and every
+// subscribed client (one per open /events connection) owned by the same
+// user receives a copy.
+type Hub struct {
+	mu      sync.Mutex
+	clients map[*client]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{clients: make(map[*client]struct{})}
+}
+
+// Publish marshals data as the given event type and fans it out to every
+// connected client owned by userID ("" in single-tenant deployments with
+// no auth, where every client matches). Slow clients have messages
+// dropped rather than blocking the publisher.
+func (h *Hub) Publish(eventType string, data interface{}, userID string) {
+	payload, err := json.Marshal(Event{Type: eventType, Data: data})
+	if err != nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for c := range h.clients {
+		if c.userID != userID {
+			continue
+		}
+		select {
+		case c.messages <- payload:
+		default:
+		}
+	}
+}
+
+// subscribe registers a new client that will only receive events
+// published for userID.
+func (h *Hub) subscribe(userID string) *client {
+	c := &client{userID: userID, messages: make(chan []byte, 16)}
+	h.mu.Lock()
+	h.clients[c] = struct{}{}
+	h.mu.Unlock()
+	return c
+}
+
+func (h *Hub) unsubscribe(c *client) {
+	h.mu.Lock()
+	delete(h.clients, c)
+	h.mu.Unlock()
+	close(c.messages)
+}
@@ -0,0 +1,46 @@
+package events
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const heartbeatInterval = 15 * time.Second
+
+// ServeHTTP upgrades the request to a text/event-stream and streams events
+// published for userID to the client until the connection is closed.
+func (h *Hub) ServeHTTP(w http.ResponseWriter, r *http.Request, userID string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	c := h.subscribe(userID)
+	defer h.unsubscribe(c)
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case payload, ok := <-c.messages:
+			if !ok {
+				return
+			}
+			fmt.Fprintf(w, "data: %s\n\n", payload)
+			flusher.Flush()
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tanq16/expenseowl/internal/config"
+)
+
+// MaxBodySize caps the size of an import upload the server will accept.
+const MaxBodySize = 100 << 20 // 100MB
+
+// ColumnMapping maps a logical field to the header of the column holding
+// it in an uploaded file, e.g. {"date":"Posted Date","amount":"Debit"}.
+// Fields left empty fall back to ExpenseOwl's own export headers.
+type ColumnMapping struct {
+	Date     string `json:"date"`
+	Amount   string `json:"amount"`
+	Name     string `json:"name"`
+	Category string `json:"category"`
+}
+
+func (m ColumnMapping) header(field, fallback string) string {
+	if field != "" {
+		return field
+	}
+	return fallback
+}
+
+// RowError records why a single input row was skipped.
+type RowError struct {
+	Row    int    `json:"row"`
+	Reason string `json:"reason"`
+}
+
+// Summary is the result of an import or dry run.
+type Summary struct {
+	Imported int        `json:"imported"`
+	Skipped  int        `json:"skipped"`
+	Errors   []RowError `json:"errors"`
+}
+
+// ParseCSV reads CSV rows according to mapping and returns the parsed
+// expenses alongside any per-row errors. The header row is required.
+func ParseCSV(r io.Reader, mapping ColumnMapping) ([]*config.Expense, []RowError) {
+	reader := csv.NewReader(bufio.NewReader(r))
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, []RowError{{Row: 0, Reason: "failed to read header row"}}
+	}
+	index := make(map[string]int, len(header))
+	for i, col := range header {
+		index[strings.TrimSpace(col)] = i
+	}
+	dateCol := mapping.header(mapping.Date, "Date")
+	amountCol := mapping.header(mapping.Amount, "Amount")
+	nameCol := mapping.header(mapping.Name, "Name")
+	categoryCol := mapping.header(mapping.Category, "Category")
+
+	var expenses []*config.Expense
+	var errs []RowError
+	for rowNum := 1; ; rowNum++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			errs = append(errs, RowError{Row: rowNum, Reason: err.Error()})
+			continue
+		}
+		expense, err := rowToExpense(record, index, dateCol, amountCol, nameCol, categoryCol)
+		if err != nil {
+			errs = append(errs, RowError{Row: rowNum, Reason: err.Error()})
+			continue
+		}
+		expenses = append(expenses, expense)
+	}
+	return expenses, errs
+}
+
+func rowToExpense(record []string, index map[string]int, dateCol, amountCol, nameCol, categoryCol string) (*config.Expense, error) {
+	get := func(col string) string {
+		i, ok := index[col]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+	rawDate := get(dateCol)
+	date, err := parseDate(rawDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q: %w", rawDate, err)
+	}
+	rawAmount := get(amountCol)
+	amount, err := parseAmount(rawAmount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount %q: %w", rawAmount, err)
+	}
+	return &config.Expense{
+		Name:     get(nameCol),
+		Category: get(categoryCol),
+		Amount:   amount,
+		Date:     date,
+	}, nil
+}
+
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"01/02/2006",
+	"1/2/2006",
+}
+
+func parseDate(raw string) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date format")
+}
+
+// parseAmount handles common bank-export conventions: currency symbols,
+// thousands separators, and parentheses-as-negative (e.g. "($12.34)" is
+// -12.34).
+func parseAmount(raw string) (float64, error) {
+	s := strings.TrimSpace(raw)
+	if s == "" {
+		return 0, fmt.Errorf("empty amount")
+	}
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative = true
+		s = s[1 : len(s)-1]
+	}
+	for _, sym := range []string{"$", "€", "£", "¥", ","} {
+		s = strings.ReplaceAll(s, sym, "")
+	}
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "-") {
+		negative = true
+		s = strings.TrimPrefix(s, "-")
+	}
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	if negative {
+		value = -value
+	}
+	return value, nil
+}
+
+// DedupKey is the hash used to detect duplicate expenses across imports:
+// same date, amount, and name.
+func DedupKey(e *config.Expense) string {
+	return fmt.Sprintf("%s|%.2f|%s", e.Date.UTC().Format("2006-01-02"), e.Amount, strings.ToLower(strings.TrimSpace(e.Name)))
+}
@@ -0,0 +1,68 @@
+package importer
+
+import "testing"
+
+func TestParseAmountHandlesParenthesesAsNegative(t *testing.T) {
+	got, err := parseAmount("($12.34)")
+	if err != nil {
+		t.Fatalf("parseAmount: %v", err)
+	}
+	if got != -12.34 {
+		t.Fatalf("expected -12.34, got %v", got)
+	}
+}
+
+func TestParseAmountHandlesCurrencySymbolsAndThousandsSeparators(t *testing.T) {
+	cases := map[string]float64{
+		"$1,234.56": 1234.56,
+		"€99.00":    99.00,
+		"£5":        5,
+		"¥1,000":    1000,
+	}
+	for raw, want := range cases {
+		got, err := parseAmount(raw)
+		if err != nil {
+			t.Fatalf("parseAmount(%q): %v", raw, err)
+		}
+		if got != want {
+			t.Fatalf("parseAmount(%q) = %v, want %v", raw, got, want)
+		}
+	}
+}
+
+func TestParseAmountHandlesLeadingMinus(t *testing.T) {
+	got, err := parseAmount("-42.50")
+	if err != nil {
+		t.Fatalf("parseAmount: %v", err)
+	}
+	if got != -42.50 {
+		t.Fatalf("expected -42.50, got %v", got)
+	}
+}
+
+func TestParseAmountRejectsEmptyInput(t *testing.T) {
+	if _, err := parseAmount("   "); err == nil {
+		t.Fatalf("expected an error for empty amount input")
+	}
+}
+
+func TestParseAmountRejectsGarbage(t *testing.T) {
+	if _, err := parseAmount("not-a-number"); err == nil {
+		t.Fatalf("expected an error for unparseable amount")
+	}
+}
+
+func TestParseDateAcceptsKnownLayouts(t *testing.T) {
+	cases := []string{"2026-01-15", "01/15/2026", "1/15/2026", "2026-01-15 10:30:00"}
+	for _, raw := range cases {
+		if _, err := parseDate(raw); err != nil {
+			t.Fatalf("parseDate(%q): %v", raw, err)
+		}
+	}
+}
+
+func TestParseDateRejectsUnrecognizedFormat(t *testing.T) {
+	if _, err := parseDate("15th of January"); err == nil {
+		t.Fatalf("expected an error for an unrecognized date format")
+	}
+}
@@ -0,0 +1,58 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/tanq16/expenseowl/internal/config"
+)
+
+// ParseJSON reads a JSON array of objects (ExpenseOwl's own ExportJSON
+// format, or a generic export with a supplied ColumnMapping) and returns
+// the parsed expenses alongside any per-row errors.
+func ParseJSON(r io.Reader, mapping ColumnMapping) ([]*config.Expense, []RowError) {
+	var raw []map[string]interface{}
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, []RowError{{Row: 0, Reason: "failed to parse JSON: " + err.Error()}}
+	}
+	dateField := mapping.header(mapping.Date, "date")
+	amountField := mapping.header(mapping.Amount, "amount")
+	nameField := mapping.header(mapping.Name, "name")
+	categoryField := mapping.header(mapping.Category, "category")
+
+	var expenses []*config.Expense
+	var errs []RowError
+	for i, row := range raw {
+		rowNum := i + 1
+		rawDate := fmt.Sprintf("%v", row[dateField])
+		date, err := parseDate(rawDate)
+		if err != nil {
+			errs = append(errs, RowError{Row: rowNum, Reason: fmt.Sprintf("invalid date %q: %v", rawDate, err)})
+			continue
+		}
+		amount, err := toAmount(row[amountField])
+		if err != nil {
+			errs = append(errs, RowError{Row: rowNum, Reason: fmt.Sprintf("invalid amount: %v", err)})
+			continue
+		}
+		expenses = append(expenses, &config.Expense{
+			Name:     fmt.Sprintf("%v", row[nameField]),
+			Category: fmt.Sprintf("%v", row[categoryField]),
+			Amount:   amount,
+			Date:     date,
+		})
+	}
+	return expenses, errs
+}
+
+func toAmount(v interface{}) (float64, error) {
+	switch value := v.(type) {
+	case float64:
+		return value, nil
+	case string:
+		return parseAmount(value)
+	default:
+		return 0, fmt.Errorf("unsupported amount type %T", v)
+	}
+}
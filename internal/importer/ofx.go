@@ -0,0 +1,90 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/tanq16/expenseowl/internal/config"
+)
+
+var ofxTagLine = regexp.MustCompile(`^<([A-Za-z0-9./]+)>(.*)$`)
+
+// ParseOFX reads an OFX "lite" export: SGML-style <STMTTRN> blocks, one
+// tag per line, with or without closing tags. Recognized fields are
+// DTPOSTED (date), TRNAMT (amount), and NAME or MEMO (used as name).
+func ParseOFX(r io.Reader) ([]*config.Expense, []RowError) {
+	scanner := bufio.NewScanner(r)
+	var expenses []*config.Expense
+	var errs []RowError
+	var current map[string]string
+	rowNum := 0
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		match := ofxTagLine.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		tag, value := strings.ToUpper(match[1]), strings.TrimSpace(match[2])
+		if i := strings.Index(value, "</"); i >= 0 {
+			value = strings.TrimSpace(value[:i])
+		}
+		switch tag {
+		case "STMTTRN":
+			current = make(map[string]string)
+		case "/STMTTRN":
+			if current == nil {
+				continue
+			}
+			rowNum++
+			expense, err := ofxRecordToExpense(current)
+			if err != nil {
+				errs = append(errs, RowError{Row: rowNum, Reason: err.Error()})
+			} else {
+				expenses = append(expenses, expense)
+			}
+			current = nil
+		default:
+			if current != nil && value != "" {
+				current[tag] = value
+			}
+		}
+	}
+	return expenses, errs
+}
+
+func ofxRecordToExpense(fields map[string]string) (*config.Expense, error) {
+	date, err := parseOFXDate(fields["DTPOSTED"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q: %w", fields["DTPOSTED"], err)
+	}
+	amount, err := parseAmount(fields["TRNAMT"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount %q: %w", fields["TRNAMT"], err)
+	}
+	name := fields["NAME"]
+	if name == "" {
+		name = fields["MEMO"]
+	}
+	return &config.Expense{
+		Name:   name,
+		Amount: amount,
+		Date:   date,
+	}, nil
+}
+
+// parseOFXDate accepts the OFX DTPOSTED format YYYYMMDD[HHMMSS[.xxx[tz]]],
+// using only the leading 8-digit date portion.
+func parseOFXDate(raw string) (time.Time, error) {
+	if len(raw) < 8 {
+		return time.Time{}, fmt.Errorf("unrecognized OFX date format")
+	}
+	return time.Parse("20060102", raw[:8])
+}
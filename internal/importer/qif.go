@@ -0,0 +1,84 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tanq16/expenseowl/internal/config"
+)
+
+type qifRecord struct {
+	date, amount, name, category string
+}
+
+// ParseQIF reads a QIF "lite" export: one field per line, each record
+// terminated by a line containing only "^". Recognized fields are D
+// (date), T/U (amount), P/M (payee/memo, used as name), and L (category).
+func ParseQIF(r io.Reader) ([]*config.Expense, []RowError) {
+	scanner := bufio.NewScanner(r)
+	var expenses []*config.Expense
+	var errs []RowError
+	var current qifRecord
+	rowNum := 0
+
+	flush := func() {
+		if current == (qifRecord{}) {
+			return
+		}
+		rowNum++
+		expense, err := qifRecordToExpense(current)
+		if err != nil {
+			errs = append(errs, RowError{Row: rowNum, Reason: err.Error()})
+		} else {
+			expenses = append(expenses, expense)
+		}
+		current = qifRecord{}
+	}
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "!") {
+			continue
+		}
+		if line == "^" {
+			flush()
+			continue
+		}
+		field, value := line[0], strings.TrimSpace(line[1:])
+		switch field {
+		case 'D':
+			current.date = value
+		case 'T', 'U':
+			current.amount = value
+		case 'P':
+			current.name = value
+		case 'M':
+			if current.name == "" {
+				current.name = value
+			}
+		case 'L':
+			current.category = value
+		}
+	}
+	flush()
+	return expenses, errs
+}
+
+func qifRecordToExpense(r qifRecord) (*config.Expense, error) {
+	date, err := parseDate(r.date)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q: %w", r.date, err)
+	}
+	amount, err := parseAmount(r.amount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount %q: %w", r.amount, err)
+	}
+	return &config.Expense{
+		Name:     r.name,
+		Category: r.category,
+		Amount:   amount,
+		Date:     date,
+	}, nil
+}
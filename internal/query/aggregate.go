@@ -0,0 +1,73 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/tanq16/expenseowl/internal/config"
+)
+
+// AggregateParams selects how Aggregate buckets and summarizes expenses.
+type AggregateParams struct {
+	GroupBy string // category|month|week
+	Metric  string // sum|count|avg
+}
+
+// Bucket is one group's aggregated value, e.g. {"key":"Groceries","value":123.45}.
+type Bucket struct {
+	Key   string  `json:"key"`
+	Value float64 `json:"value"`
+}
+
+func ParseAggregateParams(groupBy, metric string) (AggregateParams, error) {
+	switch groupBy {
+	case "category", "month", "week":
+	default:
+		return AggregateParams{}, fmt.Errorf("invalid groupBy %q", groupBy)
+	}
+	switch metric {
+	case "sum", "count", "avg":
+	default:
+		return AggregateParams{}, fmt.Errorf("invalid metric %q", metric)
+	}
+	return AggregateParams{GroupBy: groupBy, Metric: metric}, nil
+}
+
+// Aggregate groups expenses by p.GroupBy and reduces each group by
+// p.Metric, returning buckets sorted by key.
+func Aggregate(expenses []*config.Expense, p AggregateParams) []Bucket {
+	sums := make(map[string]float64)
+	counts := make(map[string]int)
+	for _, e := range expenses {
+		key := bucketKey(e, p.GroupBy)
+		sums[key] += e.Amount
+		counts[key]++
+	}
+	buckets := make([]Bucket, 0, len(sums))
+	for key, sum := range sums {
+		var value float64
+		switch p.Metric {
+		case "count":
+			value = float64(counts[key])
+		case "avg":
+			value = sum / float64(counts[key])
+		default:
+			value = sum
+		}
+		buckets = append(buckets, Bucket{Key: key, Value: value})
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].Key < buckets[j].Key })
+	return buckets
+}
+
+func bucketKey(e *config.Expense, groupBy string) string {
+	switch groupBy {
+	case "month":
+		return e.Date.Format("2006-01")
+	case "week":
+		year, week := e.Date.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	default:
+		return e.Category
+	}
+}
@@ -0,0 +1,94 @@
+package query
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/tanq16/expenseowl/internal/config"
+)
+
+// Result is a page of expenses plus the cursor for the next page, if any.
+type Result struct {
+	Expenses   []*config.Expense
+	NextCursor *Cursor
+}
+
+// Apply filters, sorts, and paginates expenses according to p. expenses
+// is not mutated.
+func Apply(expenses []*config.Expense, p *Params) Result {
+	filtered := make([]*config.Expense, 0, len(expenses))
+	for _, e := range expenses {
+		if matches(e, p) {
+			filtered = append(filtered, e)
+		}
+	}
+	sortExpenses(filtered, p.SortField, p.SortDesc)
+
+	start := 0
+	if p.Cursor != nil {
+		for i, e := range filtered {
+			if e.Date.Equal(p.Cursor.LastDate) && e.ID == p.Cursor.LastID {
+				start = i + 1
+				break
+			}
+		}
+	}
+	page := filtered[start:]
+	var next *Cursor
+	if p.Limit > 0 && len(page) > p.Limit {
+		page = page[:p.Limit]
+		last := page[len(page)-1]
+		next = &Cursor{LastDate: last.Date, LastID: last.ID}
+	}
+	return Result{Expenses: page, NextCursor: next}
+}
+
+func matches(e *config.Expense, p *Params) bool {
+	if p.From != nil && e.Date.Before(*p.From) {
+		return false
+	}
+	if p.To != nil && e.Date.After(*p.To) {
+		return false
+	}
+	if len(p.Categories) > 0 && !contains(p.Categories, e.Category) {
+		return false
+	}
+	if p.MinAmount != nil && e.Amount < *p.MinAmount {
+		return false
+	}
+	if p.MaxAmount != nil && e.Amount > *p.MaxAmount {
+		return false
+	}
+	if p.Query != "" && !strings.Contains(strings.ToLower(e.Name), strings.ToLower(p.Query)) {
+		return false
+	}
+	return true
+}
+
+func contains(list []string, v string) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
+
+func sortExpenses(expenses []*config.Expense, field string, desc bool) {
+	less := func(i, j int) bool {
+		switch field {
+		case "amount":
+			return expenses[i].Amount < expenses[j].Amount
+		case "name":
+			return expenses[i].Name < expenses[j].Name
+		default:
+			return expenses[i].Date.Before(expenses[j].Date)
+		}
+	}
+	sort.SliceStable(expenses, func(i, j int) bool {
+		if desc {
+			return less(j, i)
+		}
+		return less(i, j)
+	})
+}
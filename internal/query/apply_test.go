@@ -0,0 +1,68 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tanq16/expenseowl/internal/config"
+)
+
+func makeExpenses() []*config.Expense {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	expenses := make([]*config.Expense, 0, 5)
+	for i := 0; i < 5; i++ {
+		expenses = append(expenses, &config.Expense{
+			ID:     string(rune('a' + i)),
+			Name:   "expense",
+			Amount: float64(i),
+			Date:   base.AddDate(0, 0, i),
+		})
+	}
+	return expenses
+}
+
+func TestApplyCursorPaginationReturnsSuccessivePages(t *testing.T) {
+	expenses := makeExpenses()
+	p := &Params{SortField: "date", SortDesc: false, Limit: 2}
+
+	page1 := Apply(expenses, p)
+	if len(page1.Expenses) != 2 {
+		t.Fatalf("expected page size 2, got %d", len(page1.Expenses))
+	}
+	if page1.NextCursor == nil {
+		t.Fatalf("expected a next cursor on a non-final page")
+	}
+	if page1.Expenses[0].ID != "a" || page1.Expenses[1].ID != "b" {
+		t.Fatalf("unexpected page1 order: %v, %v", page1.Expenses[0].ID, page1.Expenses[1].ID)
+	}
+
+	p2 := &Params{SortField: "date", SortDesc: false, Limit: 2, Cursor: page1.NextCursor}
+	page2 := Apply(expenses, p2)
+	if len(page2.Expenses) != 2 {
+		t.Fatalf("expected page size 2, got %d", len(page2.Expenses))
+	}
+	if page2.Expenses[0].ID != "c" || page2.Expenses[1].ID != "d" {
+		t.Fatalf("unexpected page2 order: %v, %v", page2.Expenses[0].ID, page2.Expenses[1].ID)
+	}
+
+	p3 := &Params{SortField: "date", SortDesc: false, Limit: 2, Cursor: page2.NextCursor}
+	page3 := Apply(expenses, p3)
+	if len(page3.Expenses) != 1 || page3.Expenses[0].ID != "e" {
+		t.Fatalf("expected the final partial page with just 'e', got %v", page3.Expenses)
+	}
+	if page3.NextCursor != nil {
+		t.Fatalf("expected no next cursor once all expenses have been returned")
+	}
+}
+
+func TestApplyCursorRoundTripsThroughEncodeDecode(t *testing.T) {
+	c := Cursor{LastDate: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), LastID: "b"}
+	encoded := EncodeCursor(c)
+	decoded, err := DecodeCursor(encoded)
+	if err != nil {
+		t.Fatalf("DecodeCursor: %v", err)
+	}
+	if !decoded.LastDate.Equal(c.LastDate) || decoded.LastID != c.LastID {
+		t.Fatalf("round-tripped cursor mismatch: got %+v, want %+v", decoded, c)
+	}
+}
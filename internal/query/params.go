@@ -0,0 +1,125 @@
+package query
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Params is the parsed set of filters, sort, and pagination options
+// accepted by GET /expenses.
+type Params struct {
+	From       *time.Time
+	To         *time.Time
+	Categories []string
+	MinAmount  *float64
+	MaxAmount  *float64
+	Query      string
+	SortField  string
+	SortDesc   bool
+	Limit      int
+	Cursor     *Cursor
+}
+
+// Cursor is the opaque keyset pagination position serialized to/from the
+// `cursor` query parameter.
+type Cursor struct {
+	LastDate time.Time `json:"lastDate"`
+	LastID   string    `json:"lastID"`
+}
+
+func EncodeCursor(c Cursor) string {
+	data, _ := json.Marshal(c)
+	return base64.URLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(s string) (*Cursor, error) {
+	data, err := base64.URLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+var sortFields = map[string]bool{"date": true, "amount": true, "name": true}
+
+// ParseParams reads filter/sort/pagination query params from r.URL.Query().
+// hasFilters reports whether any recognized param was present, so callers
+// can fall back to "return everything" when none were set.
+func ParseParams(values url.Values) (p *Params, hasFilters bool, err error) {
+	p = &Params{SortField: "date", SortDesc: true, Limit: 0}
+	if raw := values.Get("from"); raw != "" {
+		hasFilters = true
+		t, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid from date %q: %w", raw, err)
+		}
+		p.From = &t
+	}
+	if raw := values.Get("to"); raw != "" {
+		hasFilters = true
+		t, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid to date %q: %w", raw, err)
+		}
+		p.To = &t
+	}
+	if categories, ok := values["category"]; ok && len(categories) > 0 {
+		hasFilters = true
+		p.Categories = categories
+	}
+	if raw := values.Get("minAmount"); raw != "" {
+		hasFilters = true
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid minAmount %q: %w", raw, err)
+		}
+		p.MinAmount = &v
+	}
+	if raw := values.Get("maxAmount"); raw != "" {
+		hasFilters = true
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid maxAmount %q: %w", raw, err)
+		}
+		p.MaxAmount = &v
+	}
+	if q := values.Get("q"); q != "" {
+		hasFilters = true
+		p.Query = q
+	}
+	if raw := values.Get("sort"); raw != "" {
+		hasFilters = true
+		field, dir, _ := strings.Cut(raw, ":")
+		if !sortFields[field] {
+			return nil, false, fmt.Errorf("invalid sort field %q", field)
+		}
+		p.SortField = field
+		p.SortDesc = dir == "desc"
+	}
+	if raw := values.Get("limit"); raw != "" {
+		hasFilters = true
+		v, err := strconv.Atoi(raw)
+		if err != nil || v <= 0 {
+			return nil, false, fmt.Errorf("invalid limit %q", raw)
+		}
+		p.Limit = v
+	}
+	if raw := values.Get("cursor"); raw != "" {
+		hasFilters = true
+		cursor, err := DecodeCursor(raw)
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid cursor: %w", err)
+		}
+		p.Cursor = cursor
+	}
+	return p, hasFilters, nil
+}
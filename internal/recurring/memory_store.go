@@ -0,0 +1,71 @@
+package recurring
+
+import "sync"
+
+// MemoryStore is an in-memory Store, suitable for a single-process
+// deployment or as a reference implementation for a persistent backend.
+type MemoryStore struct {
+	mu          sync.Mutex
+	templates   map[string]*RecurringExpense
+	occurrences map[string]bool
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		templates:   make(map[string]*RecurringExpense),
+		occurrences: make(map[string]bool),
+	}
+}
+
+func (m *MemoryStore) GetAllRecurring() ([]*RecurringExpense, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	result := make([]*RecurringExpense, 0, len(m.templates))
+	for _, t := range m.templates {
+		copy := *t
+		result = append(result, &copy)
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) GetRecurring(id string) (*RecurringExpense, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t, ok := m.templates[id]
+	if !ok {
+		return nil, ErrRecurringNotFound
+	}
+	copy := *t
+	return &copy, nil
+}
+
+func (m *MemoryStore) SaveRecurring(expense *RecurringExpense) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	copy := *expense
+	m.templates[expense.ID] = &copy
+	return nil
+}
+
+func (m *MemoryStore) DeleteRecurring(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.templates[id]; !ok {
+		return ErrRecurringNotFound
+	}
+	delete(m.templates, id)
+	return nil
+}
+
+func (m *MemoryStore) HasOccurrence(idempotencyKey string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.occurrences[idempotencyKey], nil
+}
+
+func (m *MemoryStore) MarkOccurrence(idempotencyKey string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.occurrences[idempotencyKey] = true
+	return nil
+}
@@ -0,0 +1,36 @@
+package recurring
+
+import (
+	"time"
+
+	"github.com/tanq16/expenseowl/internal/config"
+)
+
+// ProjectionWindow is how far into the future Upcoming projects occurrences.
+const ProjectionWindow = 90 * 24 * time.Hour
+
+// Upcoming returns the expenses a template would materialize between now
+// and the end of the projection window, without persisting anything.
+func (r *RecurringExpense) Upcoming(now time.Time) []*config.Expense {
+	horizon := now.Add(ProjectionWindow)
+	due := r.StartDate
+	if !r.LastMaterialized.IsZero() {
+		due = r.next(r.LastMaterialized)
+	}
+	occurrences := r.Occurrences
+	var projected []*config.Expense
+	for !due.After(horizon) {
+		if r.EndDate != nil && due.After(*r.EndDate) {
+			break
+		}
+		if r.MaxOccurrences != nil && occurrences >= *r.MaxOccurrences {
+			break
+		}
+		if due.After(now) {
+			projected = append(projected, r.toExpense(due))
+		}
+		occurrences++
+		due = r.next(due)
+	}
+	return projected
+}
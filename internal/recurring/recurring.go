@@ -0,0 +1,111 @@
+package recurring
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tanq16/expenseowl/internal/config"
+)
+
+// Frequency is the cadence at which a RecurringExpense materializes new
+// concrete expenses.
+type Frequency string
+
+const (
+	Daily   Frequency = "daily"
+	Weekly  Frequency = "weekly"
+	Monthly Frequency = "monthly"
+	Yearly  Frequency = "yearly"
+)
+
+// RecurringExpense is a template that the scheduler materializes into
+// concrete config.Expense records on each due date.
+type RecurringExpense struct {
+	ID               string     `json:"id"`
+	UserID           string     `json:"userId,omitempty"`
+	Name             string     `json:"name"`
+	Category         string     `json:"category"`
+	Amount           float64    `json:"amount"`
+	Frequency        Frequency  `json:"frequency"`
+	StartDate        time.Time  `json:"startDate"`
+	EndDate          *time.Time `json:"endDate,omitempty"`
+	MaxOccurrences   *int       `json:"maxOccurrences,omitempty"`
+	Occurrences      int        `json:"occurrences"`
+	LastMaterialized time.Time  `json:"lastMaterialized,omitempty"`
+}
+
+// Store persists RecurringExpense templates and the idempotency keys of
+// occurrences that have already been materialized, so restarts don't
+// double-post an expense for the same due date.
+type Store interface {
+	GetAllRecurring() ([]*RecurringExpense, error)
+	GetRecurring(id string) (*RecurringExpense, error)
+	SaveRecurring(expense *RecurringExpense) error
+	DeleteRecurring(id string) error
+	HasOccurrence(idempotencyKey string) (bool, error)
+	MarkOccurrence(idempotencyKey string) error
+}
+
+var ErrRecurringNotFound = errors.New("recurring expense not found")
+
+func (r *RecurringExpense) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return errors.New("name cannot be empty")
+	}
+	if r.Amount <= 0 {
+		return errors.New("amount must be greater than zero")
+	}
+	switch r.Frequency {
+	case Daily, Weekly, Monthly, Yearly:
+	default:
+		return fmt.Errorf("invalid frequency: %s", r.Frequency)
+	}
+	if r.StartDate.IsZero() {
+		return errors.New("startDate is required")
+	}
+	if r.EndDate != nil && r.EndDate.Before(r.StartDate) {
+		return errors.New("endDate cannot be before startDate")
+	}
+	if r.MaxOccurrences != nil && *r.MaxOccurrences <= 0 {
+		return errors.New("maxOccurrences must be greater than zero")
+	}
+	return nil
+}
+
+// next returns the due date following the given date according to the
+// template's frequency.
+func (r *RecurringExpense) next(after time.Time) time.Time {
+	switch r.Frequency {
+	case Daily:
+		return after.AddDate(0, 0, 1)
+	case Weekly:
+		return after.AddDate(0, 0, 7)
+	case Monthly:
+		return after.AddDate(0, 1, 0)
+	case Yearly:
+		return after.AddDate(1, 0, 0)
+	default:
+		return after
+	}
+}
+
+// toExpense builds the concrete expense for a single due date.
+func (r *RecurringExpense) toExpense(due time.Time) *config.Expense {
+	return &config.Expense{
+		Name:     r.Name,
+		Category: r.Category,
+		Amount:   r.Amount,
+		Date:     due.UTC(),
+	}
+}
+
+// occurrenceKey returns the idempotency key for the occurrence of a
+// template due on the given date.
+func occurrenceKey(id string, due time.Time) string {
+	sum := sha256.Sum256([]byte(id + "|" + due.UTC().Format(time.RFC3339)))
+	return hex.EncodeToString(sum[:])
+}
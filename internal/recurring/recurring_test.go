@@ -0,0 +1,123 @@
+package recurring
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tanq16/expenseowl/internal/config"
+)
+
+func TestUpcomingStopsAtEndDate(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := now.AddDate(0, 0, 5)
+	r := &RecurringExpense{
+		ID:        "r1",
+		Name:      "Rent",
+		Amount:    100,
+		Frequency: Daily,
+		StartDate: now,
+		EndDate:   &end,
+	}
+	projected := r.Upcoming(now)
+	for _, e := range projected {
+		if e.Date.After(end) {
+			t.Fatalf("projected occurrence %s is after endDate %s", e.Date, end)
+		}
+	}
+	if len(projected) != 5 {
+		t.Fatalf("expected 5 occurrences before endDate, got %d", len(projected))
+	}
+}
+
+func TestUpcomingStopsAtMaxOccurrences(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	max := 2
+	r := &RecurringExpense{
+		ID:             "r2",
+		Name:           "Gym",
+		Amount:         50,
+		Frequency:      Weekly,
+		StartDate:      now,
+		MaxOccurrences: &max,
+	}
+	projected := r.Upcoming(now)
+	if len(projected) != 2 {
+		t.Fatalf("expected 2 occurrences capped by maxOccurrences, got %d", len(projected))
+	}
+}
+
+func TestUpcomingExcludesOccurrencesNotAfterNow(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := &RecurringExpense{
+		ID:        "r3",
+		Name:      "Coffee",
+		Amount:    5,
+		Frequency: Daily,
+		StartDate: now,
+	}
+	for _, e := range r.Upcoming(now) {
+		if !e.Date.After(now) {
+			t.Fatalf("Upcoming returned an occurrence not strictly after now: %s", e.Date)
+		}
+	}
+}
+
+type fakeSaver struct {
+	saved []*config.Expense
+	users []string
+}
+
+func (f *fakeSaver) SaveExpenseForUser(userID string, expense *config.Expense) error {
+	f.saved = append(f.saved, expense)
+	f.users = append(f.users, userID)
+	return nil
+}
+
+func TestSchedulerMaterializeCatchesUpMissedIntervals(t *testing.T) {
+	store := NewMemoryStore()
+	saver := &fakeSaver{}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	template := &RecurringExpense{
+		ID:        "catchup",
+		Name:      "Subscription",
+		Amount:    10,
+		Frequency: Daily,
+		StartDate: start,
+	}
+	if err := store.SaveRecurring(template); err != nil {
+		t.Fatalf("SaveRecurring: %v", err)
+	}
+
+	scheduler := NewScheduler(store, saver, time.Hour)
+	now := start.AddDate(0, 0, 3)
+	scheduler.materialize(template, now)
+
+	if len(saver.saved) != 3 {
+		t.Fatalf("expected 3 missed occurrences materialized, got %d", len(saver.saved))
+	}
+}
+
+func TestSchedulerMaterializeIsIdempotentOnRestart(t *testing.T) {
+	store := NewMemoryStore()
+	saver := &fakeSaver{}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	template := &RecurringExpense{
+		ID:        "idempotent",
+		Name:      "Subscription",
+		Amount:    10,
+		Frequency: Daily,
+		StartDate: start,
+	}
+	if err := store.SaveRecurring(template); err != nil {
+		t.Fatalf("SaveRecurring: %v", err)
+	}
+
+	scheduler := NewScheduler(store, saver, time.Hour)
+	now := start.AddDate(0, 0, 3)
+	scheduler.materialize(template, now)
+	scheduler.materialize(template, now)
+
+	if len(saver.saved) != 4 {
+		t.Fatalf("expected re-running materialize for the same window to be a no-op, got %d total saves", len(saver.saved))
+	}
+}
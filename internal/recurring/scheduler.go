@@ -0,0 +1,125 @@
+package recurring
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/tanq16/expenseowl/internal/config"
+)
+
+// Saver is the subset of storage.ScopedStorage the scheduler needs to
+// materialize a due RecurringExpense into the ledger of the user that
+// owns it ("" in single-tenant deployments with no auth).
+type Saver interface {
+	SaveExpenseForUser(userID string, expense *config.Expense) error
+}
+
+// unscopedStorage is the subset of storage.Storage a single-tenant
+// deployment (no auth, no per-user scoping) offers.
+type unscopedStorage interface {
+	SaveExpense(expense *config.Expense) error
+}
+
+// singleTenantSaver adapts an unscopedStorage backend into a Saver by
+// ignoring userID, for deployments with no auth configured.
+type singleTenantSaver struct {
+	storage unscopedStorage
+}
+
+// NewSingleTenantSaver wraps a storage.Storage-shaped backend as a Saver
+// for deployments that have no auth/per-user scoping.
+func NewSingleTenantSaver(storage unscopedStorage) Saver {
+	return singleTenantSaver{storage: storage}
+}
+
+func (s singleTenantSaver) SaveExpenseForUser(_ string, expense *config.Expense) error {
+	return s.storage.SaveExpense(expense)
+}
+
+// Scheduler periodically materializes due RecurringExpense templates into
+// concrete expenses, catching up on any intervals missed while the
+// process was offline.
+type Scheduler struct {
+	store    Store
+	saver    Saver
+	interval time.Duration
+}
+
+func NewScheduler(store Store, saver Saver, interval time.Duration) *Scheduler {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	return &Scheduler{store: store, saver: saver, interval: interval}
+}
+
+// Run blocks, ticking at the configured interval until ctx is canceled.
+// It materializes immediately on entry so missed occurrences are caught
+// up as soon as the process comes back online.
+func (s *Scheduler) Run(ctx context.Context) {
+	s.tick()
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("recurring: scheduler stopped")
+			return
+		case <-ticker.C:
+			s.tick()
+		}
+	}
+}
+
+func (s *Scheduler) tick() {
+	templates, err := s.store.GetAllRecurring()
+	if err != nil {
+		log.Printf("recurring: failed to load templates: %v\n", err)
+		return
+	}
+	now := time.Now().UTC()
+	for _, t := range templates {
+		s.materialize(t, now)
+	}
+}
+
+func (s *Scheduler) materialize(t *RecurringExpense, now time.Time) {
+	due := t.StartDate
+	if !t.LastMaterialized.IsZero() {
+		due = t.next(t.LastMaterialized)
+	}
+	changed := false
+	for !due.After(now) {
+		if t.EndDate != nil && due.After(*t.EndDate) {
+			break
+		}
+		if t.MaxOccurrences != nil && t.Occurrences >= *t.MaxOccurrences {
+			break
+		}
+		key := occurrenceKey(t.ID, due)
+		exists, err := s.store.HasOccurrence(key)
+		if err != nil {
+			log.Printf("recurring: failed to check occurrence %s: %v\n", key, err)
+			return
+		}
+		if !exists {
+			if err := s.saver.SaveExpenseForUser(t.UserID, t.toExpense(due)); err != nil {
+				log.Printf("recurring: failed to materialize %s for %s: %v\n", t.ID, due, err)
+				return
+			}
+			if err := s.store.MarkOccurrence(key); err != nil {
+				log.Printf("recurring: failed to mark occurrence %s: %v\n", key, err)
+				return
+			}
+			t.Occurrences++
+		}
+		t.LastMaterialized = due
+		changed = true
+		due = t.next(due)
+	}
+	if changed {
+		if err := s.store.SaveRecurring(t); err != nil {
+			log.Printf("recurring: failed to persist template %s: %v\n", t.ID, err)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package storage
+
+import "github.com/tanq16/expenseowl/internal/config"
+
+// ScopedStorage is implemented by storage backends that support
+// multi-user mode, where expenses and config are partitioned per
+// UserID rather than shared globally. Handler type-asserts for this
+// interface so single-tenant deployments keep working unmodified.
+type ScopedStorage interface {
+	Storage
+	GetAllExpensesForUser(userID string) ([]*config.Expense, error)
+	SaveExpenseForUser(userID string, expense *config.Expense) error
+	DeleteExpenseForUser(userID string, id string) error
+	GetConfigForUser(userID string) (*config.Config, error)
+	UpdateCategoriesForUser(userID string, categories []string) error
+	UpdateCurrencyForUser(userID string, currency string) error
+}